@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/rpc/params"
 )
@@ -45,12 +46,30 @@ func (facade *Facade) PrivateAddress(target string) (string, error) {
 // AllAddresses returns all addresses for the SSH target provided. The target
 // may be provided as a machine ID or unit name.
 func (facade *Facade) AllAddresses(target string) ([]string, error) {
+	addrs, err := facade.addressesCall("AllAddresses", target)
+	return addrs, errors.Trace(err)
+}
+
+// MachineProviderAddresses returns the addresses reported by the provider
+// for the SSH target's machine which are not yet known to Juju, such as
+// floating IPs. An empty result means that every address the provider
+// reports is already known. The target may be provided as a machine ID or
+// unit name.
+func (facade *Facade) MachineProviderAddresses(target string) ([]string, error) {
+	if facade.caller.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("MachineProviderAddresses (need V5+)")
+	}
+	addrs, err := facade.addressesCall("MachineProviderAddresses", target)
+	return addrs, errors.Trace(err)
+}
+
+func (facade *Facade) addressesCall(callName, target string) ([]string, error) {
 	entities, err := targetToEntities(target)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	var out params.SSHAddressesResults
-	err = facade.caller.FacadeCall("AllAddresses", entities, &out)
+	err = facade.caller.FacadeCall(callName, entities, &out)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -103,6 +122,41 @@ func (facade *Facade) PublicKeys(target string) ([]string, error) {
 	return out.Results[0].PublicKeys, nil
 }
 
+// MachineStatus returns the Juju machine status and the cloud instance
+// status of the machine for the SSH target provided. The target may be
+// provided as a machine ID or unit name.
+func (facade *Facade) MachineStatus(target string) (machineStatus, instanceStatus status.StatusInfo, _ error) {
+	if facade.caller.BestAPIVersion() < 5 {
+		return status.StatusInfo{}, status.StatusInfo{}, errors.NotSupportedf("MachineStatus (need V5+)")
+	}
+	entities, err := targetToEntities(target)
+	if err != nil {
+		return status.StatusInfo{}, status.StatusInfo{}, errors.Trace(err)
+	}
+	var out params.SSHMachineStatusResults
+	err = facade.caller.FacadeCall("MachineStatus", entities, &out)
+	if err != nil {
+		return status.StatusInfo{}, status.StatusInfo{}, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return status.StatusInfo{}, status.StatusInfo{}, countError(len(out.Results))
+	}
+	result := out.Results[0]
+	if err := result.Error; err != nil {
+		return status.StatusInfo{}, status.StatusInfo{}, errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return statusInfoFromParams(result.Status), statusInfoFromParams(result.InstanceStatus), nil
+}
+
+func statusInfoFromParams(in params.EntityStatus) status.StatusInfo {
+	return status.StatusInfo{
+		Status:  in.Status,
+		Message: in.Info,
+		Data:    in.Data,
+		Since:   in.Since,
+	}
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the associated model.
 func (facade *Facade) Proxy() (bool, error) {
@@ -114,6 +168,46 @@ func (facade *Facade) Proxy() (bool, error) {
 	return out.UseProxy, nil
 }
 
+// ModelProxyResult holds whether SSH connections should be proxied
+// through the controller hosts for a single model, or the error
+// encountered when determining that.
+type ModelProxyResult struct {
+	UseProxy bool
+	Error    error
+}
+
+// ProxyForModels returns whether SSH connections should be proxied through
+// the controller hosts for each of the given models. The results are in
+// the same order as the models requested.
+func (facade *Facade) ProxyForModels(modelTags []names.ModelTag) ([]ModelProxyResult, error) {
+	if facade.caller.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("ProxyForModels (need V5+)")
+	}
+	entities := params.Entities{
+		Entities: make([]params.Entity, len(modelTags)),
+	}
+	for i, tag := range modelTags {
+		entities.Entities[i].Tag = tag.String()
+	}
+	var out params.SSHProxyResults
+	err := facade.caller.FacadeCall("ProxyForModels", entities, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(modelTags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(modelTags), len(out.Results))
+	}
+	results := make([]ModelProxyResult, len(out.Results))
+	for i, result := range out.Results {
+		if result.Error != nil {
+			results[i].Error = apiservererrors.RestoreError(result.Error)
+			continue
+		}
+		results[i].UseProxy = result.UseProxy
+	}
+	return results, nil
+}
+
 func targetToEntities(target string) (params.Entities, error) {
 	tag, err := targetToTag(target)
 	if err != nil {
@@ -149,6 +243,26 @@ func (facade *Facade) ModelCredentialForSSH() (cloudspec.CloudSpec, error) {
 	if err != nil {
 		return cloudspec.CloudSpec{}, err
 	}
+	return cloudSpecFromResult(result)
+}
+
+// RefreshModelCredentialForSSH returns the model's cloud spec with its
+// credential updated to hold a freshly issued exec token, for renewing the
+// credential of a long running ssh session.
+// This facade call is only used for k8s model.
+func (facade *Facade) RefreshModelCredentialForSSH() (cloudspec.CloudSpec, error) {
+	if facade.caller.BestAPIVersion() < 5 {
+		return cloudspec.CloudSpec{}, errors.NotSupportedf("RefreshModelCredentialForSSH (need V5+)")
+	}
+	var result params.CloudSpecResult
+	err := facade.caller.FacadeCall("RefreshModelCredentialForSSH", nil, &result)
+	if err != nil {
+		return cloudspec.CloudSpec{}, err
+	}
+	return cloudSpecFromResult(result)
+}
+
+func cloudSpecFromResult(result params.CloudSpecResult) (cloudspec.CloudSpec, error) {
 	if result.Error != nil {
 		err := apiservererrors.RestoreError(result.Error)
 		return cloudspec.CloudSpec{}, err
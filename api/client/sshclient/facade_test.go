@@ -15,6 +15,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	k8scloud "github.com/juju/juju/caas/kubernetes/cloud"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/status"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/testing"
@@ -67,6 +68,30 @@ func (s *FacadeSuite) TestAddresses(c *gc.C) {
 
 }
 
+func (s *FacadeSuite) TestMachineProviderAddresses(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	expectedArg := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewMachineTag("0").String(),
+	}}}
+	res := new(params.SSHAddressesResults)
+	ress := params.SSHAddressesResults{
+		Results: []params.SSHAddressesResult{
+			{Addresses: []string{"54.1.2.3"}},
+		},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("MachineProviderAddresses", expectedArg, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	addrs, err := facade.MachineProviderAddresses("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, gc.DeepEquals, []string{"54.1.2.3"})
+}
+
 func (s *FacadeSuite) TestAddressesError(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -319,6 +344,53 @@ func (s *FacadeSuite) TestPublicKeysExtraResults(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "expected 1 result, got 2")
 }
 
+func (s *FacadeSuite) TestMachineStatus(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	expectedArg := params.Entities{[]params.Entity{{
+		names.NewUnitTag("foo/0").String(),
+	}}}
+
+	res := new(params.SSHMachineStatusResults)
+	ress := params.SSHMachineStatusResults{
+		Results: []params.SSHMachineStatusResult{{
+			Status:         params.EntityStatus{Status: status.Down, Info: "agent lost"},
+			InstanceStatus: params.EntityStatus{Status: status.Running, Info: "running"},
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("MachineStatus", expectedArg, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	machineStatus, instanceStatus, err := facade.MachineStatus("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machineStatus, gc.DeepEquals, status.StatusInfo{Status: status.Down, Message: "agent lost"})
+	c.Check(instanceStatus, gc.DeepEquals, status.StatusInfo{Status: status.Running, Message: "running"})
+}
+
+func (s *FacadeSuite) TestMachineStatusEntityError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.SSHMachineStatusResults)
+	ress := params.SSHMachineStatusResults{
+		Results: []params.SSHMachineStatusResult{{
+			Error: &params.Error{Message: `machine "0" not found`, Code: params.CodeNotFound},
+		}},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("MachineStatus", gomock.Any(), res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, _, err := facade.MachineStatus("0")
+	c.Check(err, jc.ErrorIs, errors.NotFound)
+}
+
 func (s *FacadeSuite) TestProxy(c *gc.C) {
 	checkProxy(c, true)
 	checkProxy(c, false)
@@ -354,6 +426,50 @@ func (s *FacadeSuite) TestProxyError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "boom")
 }
 
+func (s *FacadeSuite) TestProxyForModels(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	otherModelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	expectedArg := params.Entities{[]params.Entity{
+		{testing.ModelTag.String()}, {otherModelTag.String()},
+	}}
+
+	res := new(params.SSHProxyResults)
+	ress := params.SSHProxyResults{
+		Results: []params.SSHProxyResult{
+			{UseProxy: true},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("ProxyForModels", expectedArg, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	results, err := facade.ProxyForModels([]names.ModelTag{testing.ModelTag, otherModelTag})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0], gc.DeepEquals, sshclient.ModelProxyResult{UseProxy: true})
+	c.Check(results[1].UseProxy, jc.IsFalse)
+	c.Check(results[1].Error, jc.ErrorIs, apiservererrors.ErrPerm)
+}
+
+func (s *FacadeSuite) TestProxyForModelsMissingResults(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("ProxyForModels", gomock.Any(), gomock.Any()).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	results, err := facade.ProxyForModels([]names.ModelTag{testing.ModelTag})
+	c.Check(results, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "expected 1 result\\(s\\), got 0")
+}
+
 func (s *FacadeSuite) TestModelCredentialForSSH(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -408,3 +524,75 @@ func (s *FacadeSuite) TestModelCredentialForSSH(c *gc.C) {
 	}
 	c.Assert(spec, gc.DeepEquals, cloudSpec)
 }
+
+func (s *FacadeSuite) TestRefreshModelCredentialForSSH(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.CloudSpecResult)
+	ress := params.CloudSpecResult{
+		Result: &params.CloudSpec{
+			Type:     "type",
+			Name:     "name",
+			Endpoint: "endpoint",
+			Credential: &params.CloudCredential{
+				AuthType: "auth-type",
+				Attributes: map[string]string{
+					k8scloud.CredAttrToken: "new-token",
+				},
+			},
+		},
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("RefreshModelCredentialForSSH", nil, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	spec, err := facade.RefreshModelCredentialForSSH()
+	c.Assert(err, jc.ErrorIsNil)
+
+	credential := cloud.NewCredential("auth-type", map[string]string{k8scloud.CredAttrToken: "new-token"})
+	c.Assert(spec, gc.DeepEquals, environscloudspec.CloudSpec{
+		Type:       "type",
+		Name:       "name",
+		Endpoint:   "endpoint",
+		Credential: &credential,
+	})
+}
+
+func (s *FacadeSuite) TestRefreshModelCredentialForSSHError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	res := new(params.CloudSpecResult)
+	ress := params.CloudSpecResult{
+		Error: apiservererrors.ServerError(errors.NotSupportedf("facade RefreshModelCredentialForSSH for non \"caas\" model")),
+	}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(5)
+	mockFacadeCaller.EXPECT().FacadeCall("RefreshModelCredentialForSSH", nil, res).SetArg(2, ress).Return(nil)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, err := facade.RefreshModelCredentialForSSH()
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *FacadeSuite) TestV5MethodsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(4).Times(4)
+	facade := sshclient.NewFacadeFromCaller(mockFacadeCaller)
+
+	_, err := facade.MachineProviderAddresses("0")
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	_, _, err = facade.MachineStatus("0")
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	_, err = facade.ProxyForModels([]names.ModelTag{testing.ModelTag})
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	_, err = facade.RefreshModelCredentialForSSH()
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+}
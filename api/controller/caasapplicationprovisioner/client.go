@@ -110,6 +110,9 @@ func (c *Client) Life(entityName string) (life.Value, error) {
 	return results.Results[0].Life, nil
 }
 
+// WatchProvisioningInfo returns a NotifyWatcher that notifies of
+// changes to the information returned by ProvisioningInfo for the
+// specified application.
 func (c *Client) WatchProvisioningInfo(applicationName string) (watcher.NotifyWatcher, error) {
 	args := params.Entities{
 		Entities: []params.Entity{
@@ -149,6 +152,10 @@ type ProvisioningInfo struct {
 	CharmURL             *charm.URL
 	Trust                bool
 	Scale                int
+
+	// Warnings holds any non-fatal problems found while converting the
+	// provisioning info returned by the controller.
+	Warnings []string
 }
 
 // ProvisioningInfo returns the info needed to provision an operator for an application.
@@ -203,11 +210,14 @@ func (c *Client) ProvisioningInfo(applicationName string) (ProvisioningInfo, err
 	}
 
 	if r.CharmURL != "" {
+		// An unparsable charm URL shouldn't prevent the provisioner from
+		// acting on the rest of the info, so it's reported as a warning.
 		charmURL, err := charm.ParseURL(r.CharmURL)
 		if err != nil {
-			return info, errors.Trace(err)
+			info.Warnings = append(info.Warnings, fmt.Sprintf("invalid charm url %q: %v", r.CharmURL, err))
+		} else {
+			info.CharmURL = charmURL
 		}
-		info.CharmURL = charmURL
 	}
 
 	return info, nil
@@ -255,6 +265,30 @@ func (c *Client) SetOperatorStatus(appName string, status status.Status, message
 	return result.OneError()
 }
 
+// SetApplicationAndOperatorStatus updates the workload status of an
+// application and the status of its operator in a single call. Any tags
+// in the supplied status args are replaced with the application's tag.
+func (c *Client) SetApplicationAndOperatorStatus(appName string, app, operator params.EntityStatusArgs) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("SetApplicationAndOperatorStatus (need V2+)")
+	}
+	tag := names.NewApplicationTag(appName).String()
+	app.Tag = tag
+	operator.Tag = tag
+	args := params.CAASApplicationAndOperatorStatus{
+		Application: app,
+		Operator:    operator,
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetApplicationAndOperatorStatus", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != 2 {
+		return errors.Errorf("expected 2 results, got %d", len(result.Results))
+	}
+	return result.Combine()
+}
+
 // Units returns all the units for an Application.
 func (c *Client) Units(appName string) ([]params.CAASUnit, error) {
 	args := params.Entities{Entities: []params.Entity{{
@@ -208,6 +208,44 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestProvisioningInfoInvalidCharmURL(c *gc.C) {
+	vers := version.MustParse("2.99.0")
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "ProvisioningInfo")
+		c.Assert(result, gc.FitsTypeOf, &params.CAASApplicationProvisioningInfoResults{})
+		*(result.(*params.CAASApplicationProvisioningInfoResults)) = params.CAASApplicationProvisioningInfoResults{
+			Results: []params.CAASApplicationProvisioningInfo{{
+				Version:              vers,
+				APIAddresses:         []string{"10.0.0.1:1"},
+				Tags:                 map[string]string{"foo": "bar"},
+				Base:                 params.Base{Name: "ubuntu", Channel: "18.04"},
+				CharmModifiedVersion: 1,
+				CharmURL:             "ch:charm-1:bad:url",
+				Trust:                true,
+				Scale:                3,
+			}}}
+		return nil
+	})
+	info, err := client.ProvisioningInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.CharmURL, gc.IsNil)
+	c.Assert(info.Warnings, gc.HasLen, 1)
+	c.Assert(info.Warnings[0], gc.Matches, `invalid charm url "ch:charm-1:bad:url": .*`)
+	info.Warnings = nil
+	c.Assert(info, jc.DeepEquals, caasapplicationprovisioner.ProvisioningInfo{
+		Version:              vers,
+		APIAddresses:         []string{"10.0.0.1:1"},
+		Tags:                 map[string]string{"foo": "bar"},
+		Base:                 corebase.MakeDefaultBase("ubuntu", "18.04"),
+		ImageDetails:         params.ConvertDockerImageInfo(params.DockerImageInfo{}),
+		CharmModifiedVersion: 1,
+		Trust:                true,
+		Scale:                3,
+	})
+}
+
 func (s *provisionerSuite) TestApplicationOCIResources(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
@@ -288,6 +326,57 @@ func (s *provisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *provisionerSuite) TestSetApplicationAndOperatorStatus(c *gc.C) {
+	client := caasapplicationprovisioner.NewClient(basetesting.BestVersionCaller{func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetApplicationAndOperatorStatus")
+		c.Assert(arg, jc.DeepEquals, params.CAASApplicationAndOperatorStatus{
+			Application: params.EntityStatusArgs{
+				Tag:    "application-gitlab",
+				Status: "waiting",
+				Info:   "waiting for operator",
+			},
+			Operator: params.EntityStatusArgs{
+				Tag:    "application-gitlab",
+				Status: "error",
+				Info:   "broken",
+				Data:   map[string]interface{}{"foo": "bar"},
+			},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL operator"},
+			}, {
+				Error: &params.Error{Message: "FAIL application"},
+			}},
+		}
+		return nil
+	}, 2})
+
+	err := client.SetApplicationAndOperatorStatus("gitlab",
+		params.EntityStatusArgs{Status: "waiting", Info: "waiting for operator"},
+		params.EntityStatusArgs{Status: "error", Info: "broken", Data: map[string]interface{}{"foo": "bar"}},
+	)
+	c.Assert(err, gc.ErrorMatches, "FAIL operator\nFAIL application")
+}
+
+func (s *provisionerSuite) TestSetApplicationAndOperatorStatusNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected api call to %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := caasapplicationprovisioner.NewClient(apiCaller)
+
+	err := client.SetApplicationAndOperatorStatus("gitlab", params.EntityStatusArgs{}, params.EntityStatusArgs{})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
 func (s *provisionerSuite) TestAllUnits(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
@@ -404,6 +493,44 @@ func (s *provisionerSuite) TestWatchApplication(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *provisionerSuite) TestWatchProvisioningInfo(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
+		c.Check(version, gc.Equals, 1)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchProvisioningInfo")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	watcher, err := client.WatchProvisioningInfo("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchProvisioningInfoArity(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchProvisioningInfo")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{}, {}},
+		}
+		return nil
+	})
+	watcher, err := client.WatchProvisioningInfo("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, `expected 1 result when watching provisioning info for application "gitlab"`)
+}
+
 func (s *provisionerSuite) TestClearApplicationResources(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
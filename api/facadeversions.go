@@ -38,7 +38,7 @@ var facadeVersions = facades.FacadeVersions{
 	"CAASAgent":                    {2},
 	"CAASAdmission":                {1},
 	"CAASApplication":              {1},
-	"CAASApplicationProvisioner":   {1},
+	"CAASApplicationProvisioner":   {1, 2},
 	"CAASModelConfigManager":       {1},
 	"CAASFirewaller":               {1},
 	"CAASFirewallerSidecar":        {1},
@@ -124,7 +124,7 @@ var facadeVersions = facades.FacadeVersions{
 	"UserSecretsManager":           {1},
 	"Singular":                     {2},
 	"Spaces":                       {6},
-	"SSHClient":                    {4},
+	"SSHClient":                    {4, 5},
 	"StatusHistory":                {2},
 	"Storage":                      {6},
 	"StorageProvisioner":           {4},
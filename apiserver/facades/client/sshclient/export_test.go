@@ -3,4 +3,7 @@
 
 package sshclient
 
-var InternalFacade = internalFacade
+var (
+	InternalFacade = internalFacade
+	SortAddresses  = sortAddresses
+)
@@ -8,62 +8,93 @@ import (
 	"sort"
 
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 
 	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	k8scloud "github.com/juju/juju/caas/kubernetes/cloud"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
 
 type newCaasBrokerFunc func(_ stdcontext.Context, args environs.OpenParams) (Broker, error)
 
+type newInstanceListerFunc func() (InstanceLister, error)
+
 // Facade implements the API required by the sshclient worker.
 type Facade struct {
 	backend     Backend
 	authorizer  facade.Authorizer
 	callContext context.ProviderCallContext
 
-	leadershipReader leadership.Reader
-	getBroker        newCaasBrokerFunc
+	leadershipReader  leadership.Reader
+	getBroker         newCaasBrokerFunc
+	getInstanceLister newInstanceListerFunc
+}
+
+// FacadeV4 provides v4 of the sshclient API.
+type FacadeV4 struct {
+	*Facade
 }
 
 func internalFacade(
 	backend Backend, leadershipReader leadership.Reader, auth facade.Authorizer, callCtx context.ProviderCallContext,
-	getBroker newCaasBrokerFunc,
+	getBroker newCaasBrokerFunc, getInstanceLister newInstanceListerFunc,
 ) (*Facade, error) {
 	if !auth.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
 
 	return &Facade{
-		backend:          backend,
-		authorizer:       auth,
-		callContext:      callCtx,
-		leadershipReader: leadershipReader,
-		getBroker:        getBroker,
+		backend:           backend,
+		authorizer:        auth,
+		callContext:       callCtx,
+		leadershipReader:  leadershipReader,
+		getBroker:         getBroker,
+		getInstanceLister: getInstanceLister,
 	}, nil
 }
 
+// MachineProviderAddresses is not available on V4.
+func (*FacadeV4) MachineProviderAddresses(_ struct{}) {}
+
+// MachineStatus is not available on V4.
+func (*FacadeV4) MachineStatus(_ struct{}) {}
+
+// ProxyForModels is not available on V4.
+func (*FacadeV4) ProxyForModels(_ struct{}) {}
+
+// RefreshModelCredentialForSSH is not available on V4.
+func (*FacadeV4) RefreshModelCredentialForSSH(_ struct{}) {}
+
 func (facade *Facade) checkIsModelAdmin() error {
-	err := facade.authorizer.HasPermission(permission.SuperuserAccess, facade.backend.ControllerTag())
-	if err != nil && !errors.Is(err, authentication.ErrorEntityMissingPermission) {
+	isSuperuser, err := facade.isSuperuser()
+	if err != nil || isSuperuser {
 		return errors.Trace(err)
 	}
+	return facade.authorizer.HasPermission(permission.AdminAccess, facade.backend.ModelTag())
+}
 
-	if err == nil {
-		return nil
+// isSuperuser reports whether the caller has superuser access to the
+// controller.
+func (facade *Facade) isSuperuser() (bool, error) {
+	err := facade.authorizer.HasPermission(permission.SuperuserAccess, facade.backend.ControllerTag())
+	if err != nil && !errors.Is(err, authentication.ErrorEntityMissingPermission) {
+		return false, errors.Trace(err)
 	}
-
-	return facade.authorizer.HasPermission(permission.AdminAccess, facade.backend.ModelTag())
+	return err == nil, nil
 }
 
 // PublicAddress reports the preferred public network address for one
@@ -89,13 +120,20 @@ func (facade *Facade) PrivateAddress(args params.Entities) (params.SSHAddressRes
 }
 
 // AllAddresses reports all addresses that might have SSH listening for each
-// entity in args. The result is sorted with public addresses first.
-// Machines and units are supported as entity types.
+// entity in args. The result is sorted with public addresses first, unless
+// the model's ssh-address-priority config requests cloud-local addresses
+// first. Machines and units are supported as entity types.
 func (facade *Facade) AllAddresses(args params.Entities) (params.SSHAddressesResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHAddressesResults{}, errors.Trace(err)
 	}
 
+	cfg, err := facade.backend.ModelConfig()
+	if err != nil {
+		return params.SSHAddressesResults{}, errors.Trace(err)
+	}
+	priority := cfg.SSHAddressPriority()
+
 	getter := func(m SSHMachine) ([]network.SpaceAddress, error) {
 		devicesAddresses, err := m.AllDeviceSpaceAddresses()
 		if err != nil {
@@ -114,21 +152,187 @@ func (facade *Facade) AllAddresses(args params.Entities) (params.SSHAddressesRes
 			}
 		}
 
-		sort.Sort(uniqueAddresses)
+		sortAddresses(uniqueAddresses, priority)
 		return uniqueAddresses, nil
 	}
 
 	return facade.getAllEntityAddresses(args, getter)
 }
 
+// sortAddresses sorts the addresses according to the given
+// ssh-address-priority value. Addresses are ordered most public first,
+// with cloud-local addresses moved ahead of all others for local-first.
+func sortAddresses(addresses network.SpaceAddresses, priority string) {
+	sort.Sort(addresses)
+	if priority != config.SSHAddressLocalFirst {
+		return
+	}
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return addresses[i].Scope == network.ScopeCloudLocal && addresses[j].Scope != network.ScopeCloudLocal
+	})
+}
+
+// MachineProviderAddresses reports, for each entity, the addresses the
+// provider reports for the entity's machine instance that are not yet
+// recorded against the machine, such as floating IPs. An empty list means
+// that every address the provider reports is already known.
+// Machines and units are supported. This facade call is only used for
+// IAAS models. The instances of all the requested machines are fetched from
+// the provider in a single call; if that call fails, the whole call fails.
+func (facade *Facade) MachineProviderAddresses(args params.Entities) (params.SSHAddressesResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHAddressesResults{}, errors.Trace(err)
+	}
+
+	model, err := facade.backend.Model()
+	if err != nil {
+		return params.SSHAddressesResults{}, errors.Trace(err)
+	}
+	if model.Type() != state.ModelTypeIAAS {
+		return params.SSHAddressesResults{}, errors.NotSupportedf("facade MachineProviderAddresses for non %q model", state.ModelTypeIAAS)
+	}
+
+	lister, err := facade.getInstanceLister()
+	if err != nil {
+		return params.SSHAddressesResults{}, errors.Annotate(err, "getting environ")
+	}
+
+	out := params.SSHAddressesResults{
+		Results: make([]params.SSHAddressesResult, len(args.Entities)),
+	}
+	getMachine := facade.machineGetter()
+	machines := make([]SSHMachine, len(args.Entities))
+	entityInstIds := make([]instance.Id, len(args.Entities))
+	instIndex := make(map[instance.Id]int)
+	var instIds []instance.Id
+	for i, entity := range args.Entities {
+		machine, err := getMachine(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		instId, err := machine.InstanceId()
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		machines[i] = machine
+		entityInstIds[i] = instId
+		if _, ok := instIndex[instId]; !ok {
+			instIndex[instId] = len(instIds)
+			instIds = append(instIds, instId)
+		}
+	}
+	if len(instIds) == 0 {
+		return out, nil
+	}
+
+	insts, err := lister.Instances(facade.callContext, instIds)
+	switch {
+	case errors.Is(err, environs.ErrNoInstances):
+		insts = make([]instances.Instance, len(instIds))
+	case err != nil && !errors.Is(err, environs.ErrPartialInstances):
+		return params.SSHAddressesResults{}, errors.Trace(err)
+	}
+
+	for i, machine := range machines {
+		if machine == nil {
+			continue
+		}
+		inst := insts[instIndex[entityInstIds[i]]]
+		if inst == nil {
+			out.Results[i].Error = apiservererrors.ServerError(
+				errors.NotFoundf("instance %q for machine %q", entityInstIds[i], machine.MachineTag().Id()))
+			continue
+		}
+		addresses, err := facade.unknownProviderAddresses(machine, inst)
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		out.Results[i].Addresses = make([]string, len(addresses))
+		for j := range addresses {
+			out.Results[i].Addresses[j] = addresses[j].Value
+		}
+	}
+	return out, nil
+}
+
+// unknownProviderAddresses returns the addresses the provider reports for
+// the machine's instance that are not recorded against the machine.
+func (facade *Facade) unknownProviderAddresses(m SSHMachine, inst instances.Instance) (network.SpaceAddresses, error) {
+	providerAddresses, err := inst.Addresses(facade.callContext)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(providerAddresses) == 0 {
+		return nil, errors.NotFoundf("provider addresses for machine %q", m.MachineTag().Id())
+	}
+
+	known := make(map[string]bool)
+	for _, address := range m.Addresses() {
+		known[address.Value] = true
+	}
+	var addresses network.SpaceAddresses
+	for _, address := range providerAddresses {
+		if !known[address.Value] {
+			known[address.Value] = true
+			addresses = append(addresses, network.SpaceAddress{MachineAddress: address.MachineAddress})
+		}
+	}
+
+	sort.Sort(addresses)
+	return addresses, nil
+}
+
+// machineGetter returns a function which resolves an entity tag to its
+// machine through the backend, resolving each distinct tag at most once.
+// Tags which are not machine or unit tags are rejected without consulting
+// the backend.
+// It is intended to be scoped to a single facade call, so that duplicate
+// entities in one request don't cause repeated lookups.
+func (facade *Facade) machineGetter() func(tag string) (SSHMachine, error) {
+	type machineResult struct {
+		machine SSHMachine
+		err     error
+	}
+	resolved := make(map[string]machineResult)
+	return func(tag string) (SSHMachine, error) {
+		if result, ok := resolved[tag]; ok {
+			return result.machine, result.err
+		}
+		if err := validateEntityTag(tag); err != nil {
+			resolved[tag] = machineResult{err: err}
+			return nil, err
+		}
+		machine, err := facade.backend.GetMachineForEntity(tag)
+		resolved[tag] = machineResult{machine: machine, err: err}
+		return machine, err
+	}
+}
+
+// validateEntityTag checks that tagString is a well formed machine or
+// unit tag, the only entities that SSH targets can resolve to.
+func validateEntityTag(tagString string) error {
+	tag, err := names.ParseTag(tagString)
+	if err == nil {
+		switch tag.Kind() {
+		case names.MachineTagKind, names.UnitTagKind:
+			return nil
+		}
+	}
+	return errors.NotValidf("entity tag %q", tagString)
+}
+
 func (facade *Facade) getAllEntityAddresses(args params.Entities, getter func(SSHMachine) ([]network.SpaceAddress, error)) (
 	params.SSHAddressesResults, error,
 ) {
 	out := params.SSHAddressesResults{
 		Results: make([]params.SSHAddressesResult, len(args.Entities)),
 	}
+	getMachine := facade.machineGetter()
 	for i, entity := range args.Entities {
-		machine, err := facade.backend.GetMachineForEntity(entity.Tag)
+		machine, err := getMachine(entity.Tag)
 		if err != nil {
 			out.Results[i].Error = apiservererrors.ServerError(err)
 		} else {
@@ -180,6 +384,9 @@ func (facade *Facade) getAddressPerEntity(args params.Entities, addressGetter fu
 
 // PublicKeys returns the public SSH hosts for one or more
 // entities. Machines and units are supported.
+// The keys for all the resolved machines are fetched in a single backend
+// call; if that call fails, the whole call fails rather than reporting the
+// error against each entity.
 func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResults, error) {
 	if err := facade.checkIsModelAdmin(); err != nil {
 		return params.SSHPublicKeysResults{}, errors.Trace(err)
@@ -188,18 +395,77 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 	out := params.SSHPublicKeysResults{
 		Results: make([]params.SSHPublicKeysResult, len(args.Entities)),
 	}
+	getMachine := facade.machineGetter()
+
+	// Resolve every entity to its machine first, so that the keys for all
+	// of them can be fetched in one pass.
+	machineTags := make([]names.MachineTag, len(args.Entities))
+	var lookup []names.MachineTag
+	seen := make(map[names.MachineTag]bool)
 	for i, entity := range args.Entities {
-		machine, err := facade.backend.GetMachineForEntity(entity.Tag)
+		machine, err := getMachine(entity.Tag)
 		if err != nil {
 			out.Results[i].Error = apiservererrors.ServerError(err)
-		} else {
-			keys, err := facade.backend.GetSSHHostKeys(machine.MachineTag())
-			if err != nil {
-				out.Results[i].Error = apiservererrors.ServerError(err)
-			} else {
-				out.Results[i].PublicKeys = []string(keys)
-			}
+			continue
+		}
+		machineTags[i] = machine.MachineTag()
+		if !seen[machineTags[i]] {
+			seen[machineTags[i]] = true
+			lookup = append(lookup, machineTags[i])
+		}
+	}
+	if len(lookup) == 0 {
+		return out, nil
+	}
+
+	keysByMachine, err := facade.backend.GetSSHHostKeysForMachines(lookup)
+	if err != nil {
+		return params.SSHPublicKeysResults{}, errors.Trace(err)
+	}
+	for i := range args.Entities {
+		if out.Results[i].Error != nil {
+			continue
+		}
+		keys, ok := keysByMachine[machineTags[i]]
+		if !ok {
+			out.Results[i].Error = apiservererrors.ServerError(errors.NotFoundf("keys"))
+			continue
+		}
+		out.Results[i].PublicKeys = []string(keys)
+	}
+	return out, nil
+}
+
+// MachineStatus returns both the Juju machine status and the cloud
+// instance status for one or more entities, to help diagnose failed
+// SSH connections. Machines and units are supported.
+func (facade *Facade) MachineStatus(args params.Entities) (params.SSHMachineStatusResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHMachineStatusResults{}, errors.Trace(err)
+	}
+
+	out := params.SSHMachineStatusResults{
+		Results: make([]params.SSHMachineStatusResult, len(args.Entities)),
+	}
+	getMachine := facade.machineGetter()
+	for i, entity := range args.Entities {
+		machine, err := getMachine(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
 		}
+		machineStatus, err := machine.Status()
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		instanceStatus, err := machine.InstanceStatus()
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		out.Results[i].Status = common.EntityStatusFromState(machineStatus)
+		out.Results[i].InstanceStatus = common.EntityStatusFromState(instanceStatus)
 	}
 	return out, nil
 }
@@ -217,45 +483,82 @@ func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
 	return params.SSHProxyResult{UseProxy: config.ProxySSH()}, nil
 }
 
+// ProxyForModels returns whether SSH connections should be proxied
+// through the controller hosts for each of the given models. Models the
+// caller is not an admin of yield a per-model permission error rather
+// than failing the whole call.
+func (facade *Facade) ProxyForModels(args params.Entities) (params.SSHProxyResults, error) {
+	isSuperuser, err := facade.isSuperuser()
+	if err != nil {
+		return params.SSHProxyResults{}, errors.Trace(err)
+	}
+
+	out := params.SSHProxyResults{
+		Results: make([]params.SSHProxyResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		modelTag, err := names.ParseModelTag(entity.Tag)
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !isSuperuser {
+			if err := facade.authorizer.HasPermission(permission.AdminAccess, modelTag); err != nil {
+				out.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
+		config, err := facade.backend.ModelConfigForModel(modelTag.Id())
+		if err != nil {
+			out.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		out.Results[i].UseProxy = config.ProxySSH()
+	}
+	return out, nil
+}
+
 // ModelCredentialForSSH returns a cloud spec for ssh purpose.
 // This facade call is only used for k8s model.
 func (facade *Facade) ModelCredentialForSSH() (params.CloudSpecResult, error) {
-	var result params.CloudSpecResult
-
 	if err := facade.checkIsModelAdmin(); err != nil {
-		return result, err
+		return params.CloudSpecResult{}, err
 	}
+	return facade.modelCredentialForSSH("ModelCredentialForSSH"), nil
+}
 
-	model, err := facade.backend.Model()
-	if err != nil {
-		result.Error = apiservererrors.ServerError(err)
-		return result, nil
-	}
-	if model.Type() != state.ModelTypeCAAS {
-		result.Error = apiservererrors.ServerError(errors.NotSupportedf("facade ModelCredentialForSSH for non %q model", state.ModelTypeCAAS))
-		return result, nil
+// RefreshModelCredentialForSSH returns the model's cloud spec with its
+// credential updated to hold a freshly issued exec token, for renewing the
+// credential of a long running ssh session. The spec is always built from
+// the model's own cloud spec, never from anything supplied by the client.
+// This facade call is only used for k8s model.
+func (facade *Facade) RefreshModelCredentialForSSH() (params.CloudSpecResult, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.CloudSpecResult{}, err
 	}
+	return facade.modelCredentialForSSH("RefreshModelCredentialForSSH"), nil
+}
 
-	spec, err := facade.backend.CloudSpec()
+// modelCredentialForSSH returns the cloud spec of the CAAS model with its
+// credential updated to hold a freshly issued exec token.
+func (facade *Facade) modelCredentialForSSH(callName string) params.CloudSpecResult {
+	var result params.CloudSpecResult
+
+	model, spec, err := facade.caasModelCloudSpec(callName)
 	if err != nil {
 		result.Error = apiservererrors.ServerError(err)
-		return result, nil
-	}
-	if spec.Credential == nil {
-		result.Error = apiservererrors.ServerError(errors.NotValidf("cloud spec %q has empty credential", spec.Name))
-		return result, nil
+		return result
 	}
-
 	token, err := facade.getExecSecretToken(spec, model)
 	if err != nil {
 		result.Error = apiservererrors.ServerError(err)
-		return result, nil
+		return result
 	}
 
 	cred, err := k8scloud.UpdateCredentialWithToken(*spec.Credential, token)
 	if err != nil {
 		result.Error = apiservererrors.ServerError(err)
-		return result, nil
+		return result
 	}
 	result.Result = &params.CloudSpec{
 		Type:             spec.Type,
@@ -272,7 +575,28 @@ func (facade *Facade) ModelCredentialForSSH() (params.CloudSpecResult, error) {
 		SkipTLSVerify:     spec.SkipTLSVerify,
 		IsControllerCloud: spec.IsControllerCloud,
 	}
-	return result, nil
+	return result
+}
+
+// caasModelCloudSpec returns the model and its cloud spec, ensuring
+// the model is a CAAS model and the cloud spec has a credential.
+func (facade *Facade) caasModelCloudSpec(callName string) (Model, environscloudspec.CloudSpec, error) {
+	model, err := facade.backend.Model()
+	if err != nil {
+		return nil, environscloudspec.CloudSpec{}, errors.Trace(err)
+	}
+	if model.Type() != state.ModelTypeCAAS {
+		return nil, environscloudspec.CloudSpec{}, errors.NotSupportedf("facade %s for non %q model", callName, state.ModelTypeCAAS)
+	}
+
+	spec, err := facade.backend.CloudSpec()
+	if err != nil {
+		return nil, environscloudspec.CloudSpec{}, errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return nil, environscloudspec.CloudSpec{}, errors.NotValidf("cloud spec %q has empty credential", spec.Name)
+	}
+	return model, spec, nil
 }
 
 func (facade *Facade) getExecSecretToken(cloudSpec environscloudspec.CloudSpec, model Model) (string, error) {
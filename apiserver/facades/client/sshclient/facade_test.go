@@ -21,12 +21,15 @@ import (
 	k8scloud "github.com/juju/juju/caas/kubernetes/cloud"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 	environscontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -59,20 +62,20 @@ func (s *facadeSuite) SetUpTest(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("igor")
 	s.authorizer.AdminTag = names.NewUserTag("igor")
 
-	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil)
+	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
 
 func (s *facadeSuite) TestMachineAuthNotAllowed(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
-	_, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil)
+	_, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
 	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
 }
 
 func (s *facadeSuite) TestUnitAuthNotAllowed(c *gc.C) {
 	s.authorizer.Tag = names.NewUnitTag("foo/0")
-	_, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil)
+	_, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
 	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
 }
 
@@ -82,7 +85,7 @@ func (s *facadeSuite) TestNonAuthUserDenied(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("jeremy")
 	s.authorizer.AdminTag = names.NewUserTag("igor")
 
-	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil)
+	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 
@@ -101,7 +104,7 @@ func (s *facadeSuite) TestSuperUserAuth(c *gc.C) {
 	s.authorizer.Tag = names.NewUserTag("superuser-jeremy")
 	s.authorizer.AdminTag = names.NewUserTag("igor")
 
-	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil)
+	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 
@@ -147,6 +150,34 @@ func (s *facadeSuite) TestPublicAddress(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestPublicAddressInvalidEntityTags(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{
+			{s.m0},
+			{""},
+			{names.NewApplicationTag("foo").String()},
+			{"foo/0"},
+			{s.uFoo},
+		},
+	}
+	results, err := s.facade.PublicAddress(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressResults{
+		Results: []params.SSHAddressResult{
+			{Address: "1.1.1.1"},
+			{Error: &params.Error{Message: `entity tag "" not valid`, Code: params.CodeNotValid}},
+			{Error: &params.Error{Message: `entity tag "application-foo" not valid`, Code: params.CodeNotValid}},
+			{Error: &params.Error{Message: `entity tag "foo/0" not valid`, Code: params.CodeNotValid}},
+			{Address: "3.3.3.3"},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetMachineForEntity", []interface{}{s.uFoo}},
+	})
+}
+
 func (s *facadeSuite) TestPrivateAddress(c *gc.C) {
 	args := params.Entities{
 		Entities: []params.Entity{{s.uOther}, {s.m0}, {s.uFoo}},
@@ -196,12 +227,66 @@ func (s *facadeSuite) TestAllAddresses(c *gc.C) {
 		},
 	})
 	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelConfig", []interface{}{}},
 		{"GetMachineForEntity", []interface{}{s.uOther}},
 		{"GetMachineForEntity", []interface{}{s.m0}},
 		{"GetMachineForEntity", []interface{}{s.uFoo}},
 	})
 }
 
+func (s *facadeSuite) TestAllAddressesLocalFirst(c *gc.C) {
+	s.backend.sshAddressPriority = config.SSHAddressLocalFirst
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}},
+	}
+	results, err := s.facade.AllAddresses(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressesResults{
+		Results: []params.SSHAddressesResult{
+			{Addresses: []string{
+				"0.1.2.3",
+				"2.2.2.2",
+				"1.1.1.1",
+				"9.9.9.9",
+			}},
+		},
+	})
+}
+
+func (s *facadeSuite) TestSortAddresses(c *gc.C) {
+	newAddresses := func() network.SpaceAddresses {
+		return network.SpaceAddresses{
+			network.NewSpaceAddress("10.0.0.2", network.WithScope(network.ScopeCloudLocal)),
+			network.NewSpaceAddress("127.0.0.1", network.WithScope(network.ScopeMachineLocal)),
+			network.NewSpaceAddress("54.0.0.2", network.WithScope(network.ScopePublic)),
+			network.NewSpaceAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)),
+			network.NewSpaceAddress("54.0.0.1", network.WithScope(network.ScopePublic)),
+		}
+	}
+	values := func(addresses network.SpaceAddresses) []string {
+		out := make([]string, len(addresses))
+		for i, address := range addresses {
+			out[i] = address.Value
+		}
+		return out
+	}
+
+	for _, priority := range []string{"", config.SSHAddressPublicFirst} {
+		addresses := newAddresses()
+		sshclient.SortAddresses(addresses, priority)
+		c.Check(values(addresses), gc.DeepEquals, []string{
+			"54.0.0.1", "54.0.0.2", "10.0.0.1", "10.0.0.2", "127.0.0.1",
+		})
+	}
+
+	addresses := newAddresses()
+	sshclient.SortAddresses(addresses, config.SSHAddressLocalFirst)
+	c.Check(values(addresses), gc.DeepEquals, []string{
+		"10.0.0.1", "10.0.0.2", "54.0.0.1", "54.0.0.2", "127.0.0.1",
+	})
+}
+
 func (s *facadeSuite) TestPublicKeys(c *gc.C) {
 	args := params.Entities{
 		Entities: []params.Entity{{s.m0}, {s.uOther}, {s.uFoo}},
@@ -218,10 +303,116 @@ func (s *facadeSuite) TestPublicKeys(c *gc.C) {
 	})
 	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
 		{"GetMachineForEntity", []interface{}{s.m0}},
-		{"GetSSHHostKeys", []interface{}{names.NewMachineTag("0")}},
 		{"GetMachineForEntity", []interface{}{s.uOther}},
 		{"GetMachineForEntity", []interface{}{s.uFoo}},
-		{"GetSSHHostKeys", []interface{}{names.NewMachineTag("1")}},
+		{"GetSSHHostKeysForMachines", []interface{}{[]names.MachineTag{
+			names.NewMachineTag("0"), names.NewMachineTag("1"),
+		}}},
+	})
+}
+
+func (s *facadeSuite) TestPublicKeysNoMachinesResolved(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{s.uOther}},
+	}
+	results, err := s.facade.PublicKeys(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHPublicKeysResults{
+		Results: []params.SSHPublicKeysResult{
+			{Error: apiservertesting.NotFoundError("entity")},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.uOther}},
+	})
+}
+
+func (s *facadeSuite) TestPublicKeysMachineWithoutKeys(c *gc.C) {
+	m2 := names.NewMachineTag("2").String()
+	args := params.Entities{
+		Entities: []params.Entity{{m2}, {s.m0}},
+	}
+	results, err := s.facade.PublicKeys(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHPublicKeysResults{
+		Results: []params.SSHPublicKeysResult{
+			{Error: apiservertesting.NotFoundError("keys")},
+			{PublicKeys: []string{"rsa0", "dsa0"}},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{m2}},
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetSSHHostKeysForMachines", []interface{}{[]names.MachineTag{
+			names.NewMachineTag("2"), names.NewMachineTag("0"),
+		}}},
+	})
+}
+
+func (s *facadeSuite) TestMachineStatus(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.uOther}, {s.uFoo}},
+	}
+	results, err := s.facade.MachineStatus(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHMachineStatusResults{
+		Results: []params.SSHMachineStatusResult{{
+			Status:         params.EntityStatus{Status: status.Started},
+			InstanceStatus: params.EntityStatus{Status: status.Running, Info: "running"},
+		}, {
+			Error: apiservertesting.NotFoundError("entity"),
+		}, {
+			Status:         params.EntityStatus{Status: status.Down, Info: "agent is not communicating with the server"},
+			InstanceStatus: params.EntityStatus{Status: status.ProvisioningError, Info: "instance terminated"},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetMachineForEntity", []interface{}{s.uOther}},
+		{"GetMachineForEntity", []interface{}{s.uFoo}},
+	})
+}
+
+func (s *facadeSuite) TestPublicAddressRepeatedEntity(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.uOther}, {s.m0}, {s.uOther}},
+	}
+	results, err := s.facade.PublicAddress(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressResults{
+		Results: []params.SSHAddressResult{
+			{Address: "1.1.1.1"},
+			{Error: apiservertesting.NotFoundError("entity")},
+			{Address: "1.1.1.1"},
+			{Error: apiservertesting.NotFoundError("entity")},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.m0}},
+		{"GetMachineForEntity", []interface{}{s.uOther}},
+	})
+}
+
+func (s *facadeSuite) TestPublicKeysRepeatedEntity(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{s.uFoo}, {s.uFoo}},
+	}
+	results, err := s.facade.PublicKeys(args)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHPublicKeysResults{
+		Results: []params.SSHPublicKeysResult{
+			{PublicKeys: []string{"rsa1", "dsa1"}},
+			{PublicKeys: []string{"rsa1", "dsa1"}},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"GetMachineForEntity", []interface{}{s.uFoo}},
+		{"GetSSHHostKeysForMachines", []interface{}{[]names.MachineTag{names.NewMachineTag("1")}}},
 	})
 }
 
@@ -245,6 +436,62 @@ func (s *facadeSuite) TestProxyFalse(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestProxyForModels(c *gc.C) {
+	otherModelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	missingModelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00f")
+	s.backend.modelProxySSH = map[string]bool{
+		testing.ModelTag.Id(): true,
+		otherModelTag.Id():    false,
+	}
+
+	results, err := s.facade.ProxyForModels(params.Entities{
+		Entities: []params.Entity{
+			{testing.ModelTag.String()},
+			{otherModelTag.String()},
+			{missingModelTag.String()},
+			{s.m0},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], gc.DeepEquals, params.SSHProxyResult{UseProxy: true})
+	c.Check(results.Results[1], gc.DeepEquals, params.SSHProxyResult{UseProxy: false})
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelConfigForModel", []interface{}{testing.ModelTag.Id()}},
+		{"ModelConfigForModel", []interface{}{otherModelTag.Id()}},
+		{"ModelConfigForModel", []interface{}{missingModelTag.Id()}},
+	})
+}
+
+func (s *facadeSuite) TestProxyForModelsPermissionDenied(c *gc.C) {
+	otherModelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	s.backend.modelProxySSH = map[string]bool{
+		testing.ModelTag.Id(): true,
+		otherModelTag.Id():    true,
+	}
+	s.authorizer.Tag = names.NewUserTag("admin-" + testing.ModelTag.String())
+	s.authorizer.AdminTag = names.UserTag{}
+
+	facade, err := sshclient.InternalFacade(s.backend, nil, s.authorizer, s.callContext, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ProxyForModels(params.Entities{
+		Entities: []params.Entity{{otherModelTag.String()}, {testing.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHProxyResults{
+		Results: []params.SSHProxyResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{UseProxy: true},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelConfigForModel", []interface{}{testing.ModelTag.Id()}},
+	})
+}
+
 func (s *facadeSuite) TestModelCredentialForSSHFailedNotAuthorized(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -264,6 +511,7 @@ func (s *facadeSuite) TestModelCredentialForSSHFailedNotAuthorized(c *gc.C) {
 		func(context.Context, environs.OpenParams) (sshclient.Broker, error) {
 			return broker, nil
 		},
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := facade.ModelCredentialForSSH()
@@ -294,6 +542,7 @@ func (s *facadeSuite) TestModelCredentialForSSHFailedNonCAASModel(c *gc.C) {
 		func(context.Context, environs.OpenParams) (sshclient.Broker, error) {
 			return broker, nil
 		},
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := facade.ModelCredentialForSSH()
@@ -336,6 +585,7 @@ func (s *facadeSuite) TestModelCredentialForSSHFailedBadCredential(c *gc.C) {
 		func(context.Context, environs.OpenParams) (sshclient.Broker, error) {
 			return broker, nil
 		},
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := facade.ModelCredentialForSSH()
@@ -416,6 +666,7 @@ func (s *facadeSuite) assertModelCredentialForSSH(c *gc.C, f func(authorizer *mo
 			c.Assert(arg.Cloud, gc.DeepEquals, cloudSpec)
 			return broker, nil
 		},
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	result, err := facade.ModelCredentialForSSH()
@@ -441,9 +692,290 @@ func (s *facadeSuite) assertModelCredentialForSSH(c *gc.C, f func(authorizer *mo
 	})
 }
 
+func (s *facadeSuite) TestRefreshModelCredentialForSSHFailedNonCAASModel(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+	authorizer := mocks.NewMockAuthorizer(ctrl)
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+
+	gomock.InOrder(
+		authorizer.EXPECT().AuthClient().Return(true),
+		authorizer.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(nil),
+		backend.EXPECT().Model().Return(model, nil),
+		model.EXPECT().Type().Return(state.ModelTypeIAAS),
+	)
+	facade, err := sshclient.InternalFacade(backend, nil, authorizer, s.callContext, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := facade.RefreshModelCredentialForSSH()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiservererrors.RestoreError(result.Error), gc.ErrorMatches, `facade RefreshModelCredentialForSSH for non "caas" model not supported`)
+	c.Assert(result.Result, gc.IsNil)
+}
+
+func (s *facadeSuite) TestRefreshModelCredentialForSSHFailedNotAuthorized(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	authorizer := mocks.NewMockAuthorizer(ctrl)
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	backend.EXPECT().ModelTag().Return(testing.ModelTag)
+
+	gomock.InOrder(
+		authorizer.EXPECT().AuthClient().Return(true),
+		authorizer.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(authentication.ErrorEntityMissingPermission),
+		authorizer.EXPECT().HasPermission(permission.AdminAccess, testing.ModelTag).Return(apiservererrors.ErrPerm),
+	)
+	facade, err := sshclient.InternalFacade(backend, nil, authorizer, s.callContext, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := facade.RefreshModelCredentialForSSH()
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+	c.Assert(result, gc.DeepEquals, params.CloudSpecResult{})
+}
+
+func (s *facadeSuite) TestRefreshModelCredentialForSSH(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+	authorizer := mocks.NewMockAuthorizer(ctrl)
+	broker := mocks.NewMockBroker(ctrl)
+
+	credential := cloud.NewCredential(
+		"auth-type",
+		map[string]string{
+			k8scloud.CredAttrToken: "old-token",
+		},
+	)
+	cloudSpec := environscloudspec.CloudSpec{
+		Type:           "type",
+		Name:           "name",
+		Endpoint:       "endpoint",
+		Credential:     &credential,
+		CACertificates: []string{testing.CACert},
+	}
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	model.EXPECT().ControllerUUID().Return(testing.ControllerTag.Id())
+
+	gomock.InOrder(
+		authorizer.EXPECT().AuthClient().Return(true),
+		authorizer.EXPECT().HasPermission(permission.SuperuserAccess, testing.ControllerTag).Return(nil),
+		backend.EXPECT().Model().Return(model, nil),
+		model.EXPECT().Type().Return(state.ModelTypeCAAS),
+		backend.EXPECT().CloudSpec().Return(cloudSpec, nil),
+		model.EXPECT().Config().Return(nil, nil),
+		broker.EXPECT().GetSecretToken(k8sprovider.ExecRBACResourceName).Return("new-token", nil),
+	)
+	facade, err := sshclient.InternalFacade(backend, nil, authorizer, s.callContext,
+		func(_ context.Context, arg environs.OpenParams) (sshclient.Broker, error) {
+			c.Assert(arg.Cloud, gc.DeepEquals, cloudSpec)
+			return broker, nil
+		},
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := facade.RefreshModelCredentialForSSH()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.DeepEquals, &params.CloudSpec{
+		Type:     "type",
+		Name:     "name",
+		Endpoint: "endpoint",
+		Credential: &params.CloudCredential{
+			AuthType: "auth-type",
+			Attributes: map[string]string{
+				k8scloud.CredAttrUsername: "",
+				k8scloud.CredAttrPassword: "",
+				k8scloud.CredAttrToken:    "new-token",
+			},
+		},
+		CACertificates: []string{testing.CACert},
+	})
+}
+
+func (s *facadeSuite) TestMachineProviderAddresses(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+	lister := mocks.NewMockInstanceLister(ctrl)
+
+	m0 := &mockMachine{
+		tag:        names.NewMachineTag("0"),
+		instanceId: "inst-0",
+		addresses: network.SpaceAddresses{
+			network.NewSpaceAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)),
+		},
+	}
+	m1 := &mockMachine{
+		tag:        names.NewMachineTag("1"),
+		instanceId: "inst-1",
+	}
+	m2 := &mockMachine{
+		tag: names.NewMachineTag("2"),
+	}
+	m4 := &mockMachine{
+		tag:        names.NewMachineTag("4"),
+		instanceId: "inst-4",
+		addresses: network.SpaceAddresses{
+			network.NewSpaceAddress("10.0.0.4", network.WithScope(network.ScopeCloudLocal)),
+		},
+	}
+	m5 := &mockMachine{
+		tag:        names.NewMachineTag("5"),
+		instanceId: "inst-5",
+	}
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	gomock.InOrder(
+		backend.EXPECT().Model().Return(model, nil),
+		model.EXPECT().Type().Return(state.ModelTypeIAAS),
+		backend.EXPECT().GetMachineForEntity(s.m0).Return(m0, nil),
+		backend.EXPECT().GetMachineForEntity(s.uFoo).Return(m1, nil),
+		backend.EXPECT().GetMachineForEntity(s.uOther).Return(m2, nil),
+		backend.EXPECT().GetMachineForEntity("machine-3").Return(nil, errors.NotFoundf("machine 3")),
+		backend.EXPECT().GetMachineForEntity("machine-4").Return(m4, nil),
+		backend.EXPECT().GetMachineForEntity("machine-5").Return(m5, nil),
+		lister.EXPECT().Instances(s.callContext, []instance.Id{"inst-0", "inst-1", "inst-4", "inst-5"}).Return([]instances.Instance{
+			&mockInstance{addresses: network.ProviderAddresses{
+				network.NewMachineAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)).AsProviderAddress(),
+				network.NewMachineAddress("54.1.2.3", network.WithScope(network.ScopePublic)).AsProviderAddress(),
+			}},
+			&mockInstance{},
+			&mockInstance{addresses: network.ProviderAddresses{
+				network.NewMachineAddress("10.0.0.4", network.WithScope(network.ScopeCloudLocal)).AsProviderAddress(),
+			}},
+			nil,
+		}, environs.ErrPartialInstances),
+	)
+
+	s.authorizer.Tag = names.NewUserTag("superuser-igor")
+	facade, err := sshclient.InternalFacade(backend, nil, s.authorizer, s.callContext, nil,
+		func() (sshclient.InstanceLister, error) {
+			return lister, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.MachineProviderAddresses(params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.uFoo}, {s.uOther}, {"machine-3"}, {"machine-4"}, {"machine-5"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressesResults{
+		Results: []params.SSHAddressesResult{
+			{Addresses: []string{"54.1.2.3"}},
+			{Error: apiservertesting.NotFoundError(`provider addresses for machine "1"`)},
+			{Error: apiservertesting.NotProvisionedError("2")},
+			{Error: apiservertesting.NotFoundError("machine 3")},
+			{Addresses: []string{}},
+			{Error: apiservertesting.NotFoundError(`instance "inst-5" for machine "5"`)},
+		},
+	})
+}
+
+func (s *facadeSuite) TestMachineProviderAddressesNoInstances(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+	lister := mocks.NewMockInstanceLister(ctrl)
+
+	m0 := &mockMachine{
+		tag:        names.NewMachineTag("0"),
+		instanceId: "inst-0",
+	}
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	gomock.InOrder(
+		backend.EXPECT().Model().Return(model, nil),
+		model.EXPECT().Type().Return(state.ModelTypeIAAS),
+		backend.EXPECT().GetMachineForEntity(s.m0).Return(m0, nil),
+		lister.EXPECT().Instances(s.callContext, []instance.Id{"inst-0"}).Return(nil, environs.ErrNoInstances),
+	)
+
+	s.authorizer.Tag = names.NewUserTag("superuser-igor")
+	facade, err := sshclient.InternalFacade(backend, nil, s.authorizer, s.callContext, nil,
+		func() (sshclient.InstanceLister, error) {
+			return lister, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.MachineProviderAddresses(params.Entities{
+		Entities: []params.Entity{{s.m0}, {s.m0}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHAddressesResults{
+		Results: []params.SSHAddressesResult{
+			{Error: apiservertesting.NotFoundError(`instance "inst-0" for machine "0"`)},
+			{Error: apiservertesting.NotFoundError(`instance "inst-0" for machine "0"`)},
+		},
+	})
+}
+
+func (s *facadeSuite) TestMachineProviderAddressesInstancesError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+	lister := mocks.NewMockInstanceLister(ctrl)
+
+	m0 := &mockMachine{
+		tag:        names.NewMachineTag("0"),
+		instanceId: "inst-0",
+	}
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	gomock.InOrder(
+		backend.EXPECT().Model().Return(model, nil),
+		model.EXPECT().Type().Return(state.ModelTypeIAAS),
+		backend.EXPECT().GetMachineForEntity(s.m0).Return(m0, nil),
+		lister.EXPECT().Instances(s.callContext, []instance.Id{"inst-0"}).Return(nil, errors.New("boom")),
+	)
+
+	s.authorizer.Tag = names.NewUserTag("superuser-igor")
+	facade, err := sshclient.InternalFacade(backend, nil, s.authorizer, s.callContext, nil,
+		func() (sshclient.InstanceLister, error) {
+			return lister, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.MachineProviderAddresses(params.Entities{
+		Entities: []params.Entity{{s.m0}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *facadeSuite) TestMachineProviderAddressesFailedNonIAASModel(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	backend := mocks.NewMockBackend(ctrl)
+	model := mocks.NewMockModel(ctrl)
+
+	backend.EXPECT().ControllerTag().Return(testing.ControllerTag)
+	backend.EXPECT().Model().Return(model, nil)
+	model.EXPECT().Type().Return(state.ModelTypeCAAS)
+
+	s.authorizer.Tag = names.NewUserTag("superuser-igor")
+	facade, err := sshclient.InternalFacade(backend, nil, s.authorizer, s.callContext, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.MachineProviderAddresses(params.Entities{
+		Entities: []params.Entity{{s.m0}},
+	})
+	c.Assert(err, gc.ErrorMatches, `facade MachineProviderAddresses for non "iaas" model not supported`)
+}
+
 type mockBackend struct {
-	stub     jujutesting.Stub
-	proxySSH bool
+	stub               jujutesting.Stub
+	proxySSH           bool
+	sshAddressPriority string
+	modelProxySSH      map[string]bool
 }
 
 func (backend *mockBackend) ModelTag() names.ModelTag {
@@ -466,6 +998,9 @@ func (backend *mockBackend) ModelConfig() (*config.Config, error) {
 	backend.stub.AddCall("ModelConfig")
 	attrs := testing.FakeConfig()
 	attrs["proxy-ssh"] = backend.proxySSH
+	if backend.sshAddressPriority != "" {
+		attrs[config.SSHAddressPriorityKey] = backend.sshAddressPriority
+	}
 	conf, err := config.New(config.NoDefaults, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -473,6 +1008,17 @@ func (backend *mockBackend) ModelConfig() (*config.Config, error) {
 	return conf, nil
 }
 
+func (backend *mockBackend) ModelConfigForModel(modelUUID string) (*config.Config, error) {
+	backend.stub.AddCall("ModelConfigForModel", modelUUID)
+	proxySSH, ok := backend.modelProxySSH[modelUUID]
+	if !ok {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	attrs := testing.FakeConfig()
+	attrs["proxy-ssh"] = proxySSH
+	return config.New(config.NoDefaults, attrs)
+}
+
 func (backend *mockBackend) GetMachineForEntity(tagString string) (sshclient.SSHMachine, error) {
 	backend.stub.AddCall("GetMachineForEntity", tagString)
 	switch tagString {
@@ -489,6 +1035,8 @@ func (backend *mockBackend) GetMachineForEntity(tagString string) (sshclient.SSH
 				network.NewSpaceAddress("1.1.1.1", network.WithScope(network.ScopePublic)),
 				network.NewSpaceAddress("2.2.2.2", network.WithScope(network.ScopeCloudLocal)),
 			},
+			status:         status.StatusInfo{Status: status.Started},
+			instanceStatus: status.StatusInfo{Status: status.Running, Message: "running"},
 		}, nil
 	case names.NewUnitTag("foo/0").String():
 		return &mockMachine{
@@ -503,35 +1051,56 @@ func (backend *mockBackend) GetMachineForEntity(tagString string) (sshclient.SSH
 				network.NewSpaceAddress("3.3.3.3", network.WithScope(network.ScopePublic)),
 				network.NewSpaceAddress("4.4.4.4", network.WithScope(network.ScopeCloudLocal)),
 			},
+			status:         status.StatusInfo{Status: status.Down, Message: "agent is not communicating with the server"},
+			instanceStatus: status.StatusInfo{Status: status.ProvisioningError, Message: "instance terminated"},
+		}, nil
+	case names.NewMachineTag("2").String():
+		// Machine 2 has no SSH host keys recorded.
+		return &mockMachine{
+			tag: names.NewMachineTag("2"),
 		}, nil
 	}
 	return nil, errors.NotFoundf("entity")
 }
 
-func (backend *mockBackend) GetSSHHostKeys(tag names.MachineTag) (state.SSHHostKeys, error) {
-	backend.stub.AddCall("GetSSHHostKeys", tag)
-	switch tag {
-	case names.NewMachineTag("0"):
-		return state.SSHHostKeys{"rsa0", "dsa0"}, nil
-	case names.NewMachineTag("1"):
-		return state.SSHHostKeys{"rsa1", "dsa1"}, nil
+func (backend *mockBackend) GetSSHHostKeysForMachines(tags []names.MachineTag) (map[names.MachineTag]state.SSHHostKeys, error) {
+	backend.stub.AddCall("GetSSHHostKeysForMachines", tags)
+	result := make(map[names.MachineTag]state.SSHHostKeys)
+	for _, tag := range tags {
+		switch tag {
+		case names.NewMachineTag("0"):
+			result[tag] = state.SSHHostKeys{"rsa0", "dsa0"}
+		case names.NewMachineTag("1"):
+			result[tag] = state.SSHHostKeys{"rsa1", "dsa1"}
+		}
 	}
-	return nil, errors.New("machine not found")
+	return result, nil
 }
 
 type mockMachine struct {
 	tag            names.MachineTag
+	instanceId     instance.Id
 	publicAddress  string
 	privateAddress string
 
 	addresses           network.SpaceAddresses
 	allNetworkAddresses network.SpaceAddresses
+
+	status         status.StatusInfo
+	instanceStatus status.StatusInfo
 }
 
 func (m *mockMachine) MachineTag() names.MachineTag {
 	return m.tag
 }
 
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.tag.Id())
+	}
+	return m.instanceId, nil
+}
+
 func (m *mockMachine) PublicAddress() (network.SpaceAddress, error) {
 	return network.NewSpaceAddress(m.publicAddress, network.WithScope(network.ScopePublic)), nil
 }
@@ -547,3 +1116,20 @@ func (m *mockMachine) AllDeviceSpaceAddresses() (network.SpaceAddresses, error)
 func (m *mockMachine) Addresses() network.SpaceAddresses {
 	return m.addresses
 }
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return m.instanceStatus, nil
+}
+
+type mockInstance struct {
+	instances.Instance
+	addresses network.ProviderAddresses
+}
+
+func (i *mockInstance) Addresses(environscontext.ProviderCallContext) (network.ProviderAddresses, error) {
+	return i.addresses, nil
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/apiserver/facades/client/sshclient (interfaces: Backend,Model,Broker)
+// Source: github.com/juju/juju/apiserver/facades/client/sshclient (interfaces: Backend,Model,Broker,InstanceLister)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination mocks/state_mock.go github.com/juju/juju/apiserver/facades/client/sshclient Backend,Model,Broker
+//	mockgen -package mocks -destination mocks/state_mock.go github.com/juju/juju/apiserver/facades/client/sshclient Backend,Model,Broker,InstanceLister
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,8 +13,11 @@ import (
 	reflect "reflect"
 
 	sshclient "github.com/juju/juju/apiserver/facades/client/sshclient"
+	instance "github.com/juju/juju/core/instance"
 	cloudspec "github.com/juju/juju/environs/cloudspec"
 	config "github.com/juju/juju/environs/config"
+	context "github.com/juju/juju/environs/context"
+	instances "github.com/juju/juju/environs/instances"
 	state "github.com/juju/juju/state"
 	names "github.com/juju/names/v5"
 	gomock "go.uber.org/mock/gomock"
@@ -87,19 +90,19 @@ func (mr *MockBackendMockRecorder) GetMachineForEntity(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineForEntity", reflect.TypeOf((*MockBackend)(nil).GetMachineForEntity), arg0)
 }
 
-// GetSSHHostKeys mocks base method.
-func (m *MockBackend) GetSSHHostKeys(arg0 names.MachineTag) (state.SSHHostKeys, error) {
+// GetSSHHostKeysForMachines mocks base method.
+func (m *MockBackend) GetSSHHostKeysForMachines(arg0 []names.MachineTag) (map[names.MachineTag]state.SSHHostKeys, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSSHHostKeys", arg0)
-	ret0, _ := ret[0].(state.SSHHostKeys)
+	ret := m.ctrl.Call(m, "GetSSHHostKeysForMachines", arg0)
+	ret0, _ := ret[0].(map[names.MachineTag]state.SSHHostKeys)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSSHHostKeys indicates an expected call of GetSSHHostKeys.
-func (mr *MockBackendMockRecorder) GetSSHHostKeys(arg0 any) *gomock.Call {
+// GetSSHHostKeysForMachines indicates an expected call of GetSSHHostKeysForMachines.
+func (mr *MockBackendMockRecorder) GetSSHHostKeysForMachines(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSHHostKeys", reflect.TypeOf((*MockBackend)(nil).GetSSHHostKeys), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSSHHostKeysForMachines", reflect.TypeOf((*MockBackend)(nil).GetSSHHostKeysForMachines), arg0)
 }
 
 // Model mocks base method.
@@ -132,6 +135,21 @@ func (mr *MockBackendMockRecorder) ModelConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfig", reflect.TypeOf((*MockBackend)(nil).ModelConfig))
 }
 
+// ModelConfigForModel mocks base method.
+func (m *MockBackend) ModelConfigForModel(arg0 string) (*config.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConfigForModel", arg0)
+	ret0, _ := ret[0].(*config.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConfigForModel indicates an expected call of ModelConfigForModel.
+func (mr *MockBackendMockRecorder) ModelConfigForModel(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConfigForModel", reflect.TypeOf((*MockBackend)(nil).ModelConfigForModel), arg0)
+}
+
 // ModelTag mocks base method.
 func (m *MockBackend) ModelTag() names.ModelTag {
 	m.ctrl.T.Helper()
@@ -249,3 +267,41 @@ func (mr *MockBrokerMockRecorder) GetSecretToken(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretToken", reflect.TypeOf((*MockBroker)(nil).GetSecretToken), arg0)
 }
+
+// MockInstanceLister is a mock of InstanceLister interface.
+type MockInstanceLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstanceListerMockRecorder
+}
+
+// MockInstanceListerMockRecorder is the mock recorder for MockInstanceLister.
+type MockInstanceListerMockRecorder struct {
+	mock *MockInstanceLister
+}
+
+// NewMockInstanceLister creates a new mock instance.
+func NewMockInstanceLister(ctrl *gomock.Controller) *MockInstanceLister {
+	mock := &MockInstanceLister{ctrl: ctrl}
+	mock.recorder = &MockInstanceListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstanceLister) EXPECT() *MockInstanceListerMockRecorder {
+	return m.recorder
+}
+
+// Instances mocks base method.
+func (m *MockInstanceLister) Instances(arg0 context.ProviderCallContext, arg1 []instance.Id) ([]instances.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instances", arg0, arg1)
+	ret0, _ := ret[0].([]instances.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instances indicates an expected call of Instances.
+func (mr *MockInstanceListerMockRecorder) Instances(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instances", reflect.TypeOf((*MockInstanceLister)(nil).Instances), arg0, arg1)
+}
@@ -19,10 +19,21 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("SSHClient", 4, func(ctx facade.Context) (facade.Facade, error) {
-		return newFacade(ctx)
+		return newFacadeV4(ctx)
+	}, reflect.TypeOf((*FacadeV4)(nil)))
+	registry.MustRegister("SSHClient", 5, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacade(ctx) // Adds MachineProviderAddresses, MachineStatus, ProxyForModels and RefreshModelCredentialForSSH.
 	}, reflect.TypeOf((*Facade)(nil)))
 }
 
+func newFacadeV4(ctx facade.Context) (*FacadeV4, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV4{Facade: api}, nil
+}
+
 func newFacade(ctx facade.Context) (*Facade, error) {
 	st := ctx.State()
 	m, err := st.Model()
@@ -36,6 +47,7 @@ func newFacade(ctx facade.Context) (*Facade, error) {
 	facadeBackend := backend{
 		State:               st,
 		EnvironConfigGetter: stateenvirons.EnvironConfigGetter{Model: m},
+		pool:                ctx.StatePool(),
 		controllerTag:       m.ControllerTag(),
 		modelTag:            m.ModelTag(),
 	}
@@ -47,5 +59,8 @@ func newFacade(ctx facade.Context) (*Facade, error) {
 		func(ctx stdcontext.Context, args environs.OpenParams) (Broker, error) {
 			return caas.New(ctx, args)
 		},
+		func() (InstanceLister, error) {
+			return stateenvirons.GetNewEnvironFunc(environs.New)(m)
+		},
 	)
 }
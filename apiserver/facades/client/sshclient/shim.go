@@ -7,9 +7,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 )
@@ -17,8 +21,9 @@ import (
 // Backend defines the State API used by the sshclient facade.
 type Backend interface {
 	ModelConfig() (*config.Config, error)
+	ModelConfigForModel(modelUUID string) (*config.Config, error)
 	GetMachineForEntity(tag string) (SSHMachine, error)
-	GetSSHHostKeys(names.MachineTag) (state.SSHHostKeys, error)
+	GetSSHHostKeysForMachines([]names.MachineTag) (map[names.MachineTag]state.SSHHostKeys, error)
 	ModelTag() names.ModelTag
 	ControllerTag() names.ControllerTag
 	Model() (Model, error)
@@ -37,14 +42,23 @@ type Broker interface {
 	GetSecretToken(name string) (string, error)
 }
 
+// InstanceLister is a subset of environ, used to look up the
+// provider's view of a machine instance.
+type InstanceLister interface {
+	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
+}
+
 // SSHMachine specifies the methods on State.Machine of interest to
 // the SSHClient facade.
 type SSHMachine interface {
 	MachineTag() names.MachineTag
+	InstanceId() (instance.Id, error)
 	PublicAddress() (network.SpaceAddress, error)
 	PrivateAddress() (network.SpaceAddress, error)
 	Addresses() network.SpaceAddresses
 	AllDeviceSpaceAddresses() (network.SpaceAddresses, error)
+	Status() (status.StatusInfo, error)
+	InstanceStatus() (status.StatusInfo, error)
 }
 
 type sshMachine struct {
@@ -79,6 +93,7 @@ type backend struct {
 	*state.State
 	stateenvirons.EnvironConfigGetter
 
+	pool          *state.StatePool
 	controllerTag names.ControllerTag
 	modelTag      names.ModelTag
 }
@@ -96,6 +111,22 @@ func (b *backend) CloudSpec() (environscloudspec.CloudSpec, error) {
 	return b.EnvironConfigGetter.CloudSpec()
 }
 
+// ModelConfigForModel returns the config of the model with the given UUID,
+// which need not be the model the backend was created for.
+func (b *backend) ModelConfigForModel(modelUUID string) (*config.Config, error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.Config()
+}
+
 // ControllerTag returns the controller tag of the backend.
 func (b *backend) ControllerTag() names.ControllerTag {
 	return b.controllerTag
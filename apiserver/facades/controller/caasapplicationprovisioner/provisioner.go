@@ -57,6 +57,11 @@ type APIGroup struct {
 	*API
 }
 
+// APIGroupV1 is the backend for the CAASApplicationProvisioner facade v1.
+type APIGroupV1 struct {
+	*APIGroup
+}
+
 type NewResourceOpenerFunc func(appName string) (resources.Opener, error)
 
 type API struct {
@@ -404,6 +409,57 @@ func (a *API) setStatus(tag names.ApplicationTag, info status.StatusInfo) error
 	return app.SetOperatorStatus(info)
 }
 
+// SetApplicationAndOperatorStatus isn't on the v1 API.
+func (*APIGroupV1) SetApplicationAndOperatorStatus(_ struct{}) {}
+
+// SetApplicationAndOperatorStatus sets the operator status and the workload
+// status of an application in one call. The results hold the operator
+// status error followed by the application status error; an application
+// that can't be found fails both. Mismatched tags mean the request is
+// malformed, so that fails the whole call.
+func (a *API) SetApplicationAndOperatorStatus(args params.CAASApplicationAndOperatorStatus) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, 2),
+	}
+	if args.Application.Tag != args.Operator.Tag {
+		err := errors.NotValidf("application tag %q and operator tag %q", args.Application.Tag, args.Operator.Tag)
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	app, err := a.applicationForTag(args.Operator.Tag)
+	if err != nil {
+		results.Results[0].Error = apiservererrors.ServerError(err)
+		results.Results[1].Error = apiservererrors.ServerError(err)
+		return results, nil
+	}
+
+	operatorInfo := status.StatusInfo{
+		Status:  status.Status(args.Operator.Status),
+		Message: args.Operator.Info,
+		Data:    args.Operator.Data,
+	}
+	results.Results[0].Error = apiservererrors.ServerError(app.SetOperatorStatus(operatorInfo))
+
+	appInfo := status.StatusInfo{
+		Status:  status.Status(args.Application.Status),
+		Message: args.Application.Info,
+		Data:    args.Application.Data,
+	}
+	results.Results[1].Error = apiservererrors.ServerError(app.SetStatus(appInfo))
+	return results, nil
+}
+
+func (a *API) applicationForTag(tagString string) (Application, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
 // Units returns all the units for each application specified.
 func (a *API) Units(args params.Entities) (params.CAASUnitsResults, error) {
 	results := params.CAASUnitsResults{
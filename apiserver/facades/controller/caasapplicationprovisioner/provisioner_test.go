@@ -10,6 +10,7 @@ import (
 	charmresource "github.com/juju/charm/v12/resource"
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version/v2"
@@ -189,6 +190,54 @@ func (s *CAASApplicationProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	c.Assert(s.st.app.Calls()[0].Args[0], gc.DeepEquals, status.StatusInfo{Status: "started"})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestSetApplicationAndOperatorStatus(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url:  "ch:gitlab",
+		},
+	}
+	result, err := s.api.SetApplicationAndOperatorStatus(params.CAASApplicationAndOperatorStatus{
+		Application: params.EntityStatusArgs{
+			Tag:    "application-gitlab",
+			Status: "waiting",
+			Info:   "waiting for operator",
+		},
+		Operator: params.EntityStatusArgs{
+			Tag:    "application-gitlab",
+			Status: "started",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	s.st.app.CheckCallNames(c, "SetOperatorStatus", "SetStatus")
+	c.Assert(s.st.app.Calls()[0].Args[0], gc.DeepEquals, status.StatusInfo{Status: "started"})
+	c.Assert(s.st.app.Calls()[1].Args[0], gc.DeepEquals, status.StatusInfo{Status: "waiting", Message: "waiting for operator"})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestSetApplicationAndOperatorStatusMismatchedTags(c *gc.C) {
+	_, err := s.api.SetApplicationAndOperatorStatus(params.CAASApplicationAndOperatorStatus{
+		Application: params.EntityStatusArgs{Tag: "application-gitlab"},
+		Operator:    params.EntityStatusArgs{Tag: "application-mysql"},
+	})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *CAASApplicationProvisionerSuite) TestSetApplicationAndOperatorStatusApplicationNotFound(c *gc.C) {
+	result, err := s.api.SetApplicationAndOperatorStatus(params.CAASApplicationAndOperatorStatus{
+		Application: params.EntityStatusArgs{Tag: "application-mysql"},
+		Operator:    params.EntityStatusArgs{Tag: "application-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
 func (s *CAASApplicationProvisionerSuite) TestUnits(c *gc.C) {
 	s.st.app = &mockApplication{
 		life: state.Alive,
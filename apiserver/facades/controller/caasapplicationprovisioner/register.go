@@ -6,16 +6,30 @@ package caasapplicationprovisioner
 import (
 	"reflect"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/facade"
 )
 
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("CAASApplicationProvisioner", 1, func(ctx facade.Context) (facade.Facade, error) {
-		return newAPI(ctx)
+		return newAPIV1(ctx)
+	}, reflect.TypeOf((*APIGroupV1)(nil)))
+	registry.MustRegister("CAASApplicationProvisioner", 2, func(ctx facade.Context) (facade.Facade, error) {
+		return newAPI(ctx) // Adds SetApplicationAndOperatorStatus.
 	}, reflect.TypeOf((*APIGroup)(nil)))
 }
 
+// newAPIV1 provides the signature required for the v1 facade registration.
+func newAPIV1(ctx facade.Context) (*APIGroupV1, error) {
+	api, err := newAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIGroupV1{APIGroup: api}, nil
+}
+
 // newAPI provides the signature required for facade registration.
 func newAPI(ctx facade.Context) (*APIGroup, error) {
 	return NewStateCAASApplicationProvisionerAPI(ctx)
@@ -54,6 +54,16 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// SSHAddressPublicFirst orders the addresses offered to SSH clients
+	// with public addresses first.
+	SSHAddressPublicFirst = "public-first"
+
+	// SSHAddressLocalFirst orders the addresses offered to SSH clients
+	// with cloud-local addresses first.
+	SSHAddressLocalFirst = "local-first"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -298,6 +308,10 @@ const (
 	// specifying what ingress can be applied to offers in this model
 	SAASIngressAllowKey = "saas-ingress-allow"
 
+	// SSHAddressPriorityKey is the key for the order in which the addresses
+	// of a machine are offered to SSH clients.
+	SSHAddressPriorityKey = "ssh-address-priority"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -1636,6 +1650,16 @@ func (c *Config) SSHAllow() []string {
 	return strings.Split(allowList, ",")
 }
 
+// SSHAddressPriority returns the order in which the addresses of a
+// machine are offered to SSH clients, either SSHAddressPublicFirst or
+// SSHAddressLocalFirst. It defaults to SSHAddressPublicFirst.
+func (c *Config) SSHAddressPriority() string {
+	if priority, ok := c.defined[SSHAddressPriorityKey].(string); ok && priority != "" {
+		return priority
+	}
+	return SSHAddressPublicFirst
+}
+
 // SAASIngressAllow returns a slice of CIDRs specifying what
 // ingress can be applied to offers in this model
 func (c *Config) SAASIngressAllow() []string {
@@ -1912,9 +1936,10 @@ var alwaysOptional = schema.Defaults{
 	StorageDefaultBlockSourceKey:      schema.Omit,
 	StorageDefaultFilesystemSourceKey: schema.Omit,
 
-	"firewall-mode":     schema.Omit,
-	SSHAllowKey:         schema.Omit,
-	SAASIngressAllowKey: schema.Omit,
+	"firewall-mode":       schema.Omit,
+	SSHAllowKey:           schema.Omit,
+	SAASIngressAllowKey:   schema.Omit,
+	SSHAddressPriorityKey: schema.Omit,
 
 	"logging-config":                schema.Omit,
 	ProvisionerHarvestModeKey:       schema.Omit,
@@ -2461,6 +2486,14 @@ CIDRs specifying what ingress can be applied to offers in this model.`,
 		Type:  environschema.Tstring,
 		Group: environschema.EnvironGroup,
 	},
+	SSHAddressPriorityKey: {
+		Description: `The order in which the addresses of a machine are offered to SSH
+clients: 'public-first' prefers public addresses, 'local-first' prefers
+cloud-local addresses. (default "public-first")`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{SSHAddressPublicFirst, SSHAddressLocalFirst},
+		Group:  environschema.EnvironGroup,
+	},
 	TypeKey: {
 		Description: "Type of model, e.g. local, ec2",
 		Type:        environschema.Tstring,
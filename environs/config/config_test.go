@@ -1343,6 +1343,22 @@ func (s *ConfigSuite) TestSSHAllow(c *gc.C) {
 	c.Assert(allowlist, gc.HasLen, 0)
 }
 
+func (s *ConfigSuite) TestSSHAddressPriority(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SSHAddressPriority(), gc.Equals, config.SSHAddressPublicFirst)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.SSHAddressPriorityKey: config.SSHAddressLocalFirst,
+	})
+	c.Assert(cfg.SSHAddressPriority(), gc.Equals, config.SSHAddressLocalFirst)
+
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		config.SSHAddressPriorityKey: "private-first",
+	})
+	_, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, gc.ErrorMatches, `ssh-address-priority: expected one of \[public-first local-first\], got "private-first"`)
+}
+
 func (s *ConfigSuite) TestApplicationOfferAllowList(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	allowlist := cfg.SAASIngressAllow()
@@ -186,6 +186,13 @@ type CAASApplicationProvisioningStateArg struct {
 	ProvisioningState CAASApplicationProvisioningState `json:"provisioning-state"`
 }
 
+// CAASApplicationAndOperatorStatus holds the workload status of a CAAS
+// application and the status of its operator, to be set together.
+type CAASApplicationAndOperatorStatus struct {
+	Application EntityStatusArgs `json:"application"`
+	Operator    EntityStatusArgs `json:"operator"`
+}
+
 // CAASApplicationProvisionerConfig holds the configuration for the caasapplicationprovisioner worker.
 type CAASApplicationProvisionerConfig struct {
 	UnmanagedApplications Entities `json:"unmanaged-applications,omitempty"`
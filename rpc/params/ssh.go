@@ -17,7 +17,14 @@ type SSHHostKeys struct {
 
 // SSHProxyResult defines the response from the SSHClient.Proxy API.
 type SSHProxyResult struct {
-	UseProxy bool `json:"use-proxy"`
+	Error    *Error `json:"error,omitempty"`
+	UseProxy bool   `json:"use-proxy"`
+}
+
+// SSHProxyResults defines the response from the SSHClient.ProxyForModels
+// API, holding one result per requested model.
+type SSHProxyResults struct {
+	Results []SSHProxyResult `json:"results"`
 }
 
 // SSHAddressResults defines the response from various APIs on the
@@ -58,3 +65,19 @@ type SSHPublicKeysResult struct {
 	Error      *Error   `json:"error,omitempty"`
 	PublicKeys []string `json:"public-keys,omitempty"`
 }
+
+// SSHMachineStatusResults is used to return the Juju machine status and
+// the cloud instance status for one or more targets for the
+// SSHClient.MachineStatus API.
+type SSHMachineStatusResults struct {
+	Results []SSHMachineStatusResult `json:"results"`
+}
+
+// SSHMachineStatusResult is used to return the Juju machine status and
+// the cloud instance status for one SSH target (see
+// SSHMachineStatusResults).
+type SSHMachineStatusResult struct {
+	Error          *Error       `json:"error,omitempty"`
+	Status         EntityStatus `json:"status"`
+	InstanceStatus EntityStatus `json:"instance-status"`
+}
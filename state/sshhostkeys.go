@@ -48,6 +48,37 @@ func (st *State) GetSSHHostKeys(tag names.MachineTag) (SSHHostKeys, error) {
 	return SSHHostKeys(doc.Keys), nil
 }
 
+// GetSSHHostKeysForMachines retrieves the SSH host keys stored for each
+// of the given machines in a single query. Machines without stored keys
+// are omitted from the result.
+func (st *State) GetSSHHostKeysForMachines(tags []names.MachineTag) (map[names.MachineTag]SSHHostKeys, error) {
+	coll, closer := st.db().GetCollection(sshHostKeysC)
+	defer closer()
+
+	ids := make([]string, len(tags))
+	tagsByDocID := make(map[string]names.MachineTag, len(tags))
+	for i, tag := range tags {
+		ids[i] = machineGlobalKey(tag.Id())
+		tagsByDocID[st.docID(ids[i])] = tag
+	}
+
+	var docs []struct {
+		DocID string   `bson:"_id"`
+		Keys  []string `bson:"keys"`
+	}
+	if err := coll.Find(bson.D{{"_id", bson.D{{"$in", ids}}}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "key lookup failed")
+	}
+
+	result := make(map[names.MachineTag]SSHHostKeys, len(docs))
+	for _, doc := range docs {
+		if tag, ok := tagsByDocID[doc.DocID]; ok {
+			result[tag] = SSHHostKeys(doc.Keys)
+		}
+	}
+	return result, nil
+}
+
 // keysEqual checks if the ssh host keys are the same between two sets.
 // we shouldn't care about the order of the keys.
 func keysEqual(a, b []string) bool {
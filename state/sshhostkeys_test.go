@@ -57,6 +57,32 @@ func (s *SSHHostKeysSuite) TestModelIsolation(c *gc.C) {
 	checkGet(c, stB, tagB, keysB)
 }
 
+func (s *SSHHostKeysSuite) TestGetForMachines(c *gc.C) {
+	keys := state.SSHHostKeys{"rsa foo", "dsa bar"}
+	c.Assert(s.State.SetSSHHostKeys(s.machineTag, keys), jc.ErrorIsNil)
+	noKeysTag := s.Factory.MakeMachine(c, nil).MachineTag()
+
+	result, err := s.State.GetSSHHostKeysForMachines([]names.MachineTag{
+		s.machineTag, noKeysTag, names.NewMachineTag("100"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, map[names.MachineTag]state.SSHHostKeys{
+		s.machineTag: keys,
+	})
+}
+
+func (s *SSHHostKeysSuite) TestGetForMachinesModelIsolation(c *gc.C) {
+	stB := s.Factory.MakeModel(c, nil)
+	defer stB.Close()
+	factoryB := factory.NewFactory(stB, s.StatePool)
+	tagB := factoryB.MakeMachine(c, nil).MachineTag()
+	c.Assert(stB.SetSSHHostKeys(tagB, state.SSHHostKeys{"rsaB", "dsaB"}), jc.ErrorIsNil)
+
+	result, err := s.State.GetSSHHostKeysForMachines([]names.MachineTag{tagB})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 0)
+}
+
 func checkKeysNotFound(c *gc.C, st *state.State, tag names.MachineTag) {
 	_, err := st.GetSSHHostKeys(tag)
 	c.Check(errors.IsNotFound(err), jc.IsTrue)
@@ -133,7 +133,13 @@ func appAlive(appName string, app caas.Application, password string, lastApplied
 	if err != nil {
 		return errors.Annotate(err, "retrieving provisioning info")
 	}
+	for _, warning := range provisionInfo.Warnings {
+		logger.Warningf("provisioning info for %q: %s", appName, warning)
+	}
 	if provisionInfo.CharmURL == nil {
+		if len(provisionInfo.Warnings) > 0 {
+			return errors.Errorf("missing charm url in provision info: %s", strings.Join(provisionInfo.Warnings, "; "))
+		}
 		return errors.Errorf("missing charm url in provision info")
 	}
 
@@ -628,6 +628,33 @@ func (s *OpsSuite) TestAppAlive(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *OpsSuite) TestAppAliveInvalidCharmURL(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	app := caasmocks.NewMockApplication(ctrl)
+	facade := mocks.NewMockCAASProvisionerFacade(ctrl)
+
+	clk := testclock.NewDilatedWallClock(coretesting.ShortWait)
+	lastApplied := caas.ApplicationConfig{}
+
+	pi := api.ProvisioningInfo{
+		Warnings: []string{`invalid charm url "ch:charm-1:bad:url": cannot parse URL`},
+	}
+	facade.EXPECT().ProvisioningInfo("test").Return(pi, nil)
+
+	var logWriter loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("ops-test", &logWriter), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("ops-test")
+
+	err := caasapplicationprovisioner.AppOps.AppAlive("test", app, "123456789", &lastApplied, facade, clk, s.logger)
+	c.Assert(err, gc.ErrorMatches, `missing charm url in provision info: invalid charm url "ch:charm-1:bad:url": cannot parse URL`)
+	c.Assert(logWriter.Log(), jc.LogMatches, jc.SimpleMessages{{
+		Level:   loggo.WARNING,
+		Message: `provisioning info for "test": invalid charm url "ch:charm-1:bad:url": cannot parse URL`,
+	}})
+}
+
 func (s *OpsSuite) TestAppDying(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()